@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"demodesk/neko/internal/types"
+	"demodesk/neko/internal/utils"
+)
+
+// AuditHandler exposes an admin endpoint for tailing recent audit events
+// recorded by the session manager. It performs no authorization check of
+// its own.
+type AuditHandler struct {
+	sessions types.SessionManager
+}
+
+func NewAuditHandler(sessions types.SessionManager) *AuditHandler {
+	return &AuditHandler{
+		sessions: sessions,
+	}
+}
+
+func (h *AuditHandler) Route(r chi.Router) {
+	r.Get("/", h.auditTail)
+}
+
+func (h *AuditHandler) auditTail(w http.ResponseWriter, r *http.Request) {
+	limit := 100
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil {
+			limit = parsed
+		}
+	}
+
+	utils.HttpSuccess(w, h.sessions.AuditTail(limit))
+}
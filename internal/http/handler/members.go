@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"demodesk/neko/internal/types"
+	"demodesk/neko/internal/utils"
+)
+
+// MembersHandler exposes admin CRUD endpoints for managing members, backed
+// by the session manager's MemberStore. It performs no authorization check
+// of its own.
+type MembersHandler struct {
+	sessions types.SessionManager
+}
+
+func NewMembersHandler(sessions types.SessionManager) *MembersHandler {
+	return &MembersHandler{
+		sessions: sessions,
+	}
+}
+
+func (h *MembersHandler) Route(r chi.Router) {
+	r.Get("/", h.membersList)
+	r.Post("/", h.membersCreate)
+
+	r.Route("/{memberId}", func(r chi.Router) {
+		r.Put("/", h.membersUpdate)
+		r.Delete("/", h.membersDelete)
+		r.Post("/secret", h.membersRotateSecret)
+	})
+}
+
+type memberPayload struct {
+	Secret     string   `json:"secret"`
+	Name       string   `json:"name"`
+	IsAdmin    bool     `json:"is_admin"`
+	Roles      []string `json:"roles"`
+	CanHost    bool     `json:"can_host"`
+	CanControl bool     `json:"can_control"`
+	CanWatch   bool     `json:"can_watch"`
+}
+
+func (p memberPayload) toProfile() types.MemberProfile {
+	return types.MemberProfile{
+		Secret:     p.Secret,
+		Name:       p.Name,
+		IsAdmin:    p.IsAdmin,
+		Roles:      p.Roles,
+		CanHost:    p.CanHost,
+		CanControl: p.CanControl,
+		CanWatch:   p.CanWatch,
+	}
+}
+
+func (h *MembersHandler) membersList(w http.ResponseWriter, r *http.Request) {
+	members, err := h.sessions.ListMembers()
+	if err != nil {
+		utils.HttpInternalServerError(w, err)
+		return
+	}
+
+	utils.HttpSuccess(w, members)
+}
+
+func (h *MembersHandler) membersCreate(w http.ResponseWriter, r *http.Request) {
+	data := struct {
+		Id string `json:"id"`
+		memberPayload
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		utils.HttpBadRequest(w, err)
+		return
+	}
+
+	if err := h.sessions.CreateMember(data.Id, data.toProfile()); err != nil {
+		utils.HttpUnprocessableEntity(w, err)
+		return
+	}
+
+	utils.HttpSuccess(w)
+}
+
+func (h *MembersHandler) membersUpdate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "memberId")
+
+	data := memberPayload{}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		utils.HttpBadRequest(w, err)
+		return
+	}
+
+	if err := h.sessions.UpdateMember(id, data.toProfile()); err != nil {
+		utils.HttpUnprocessableEntity(w, err)
+		return
+	}
+
+	utils.HttpSuccess(w)
+}
+
+func (h *MembersHandler) membersDelete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "memberId")
+
+	if err := h.sessions.DeleteMember(id); err != nil {
+		utils.HttpUnprocessableEntity(w, err)
+		return
+	}
+
+	utils.HttpSuccess(w)
+}
+
+func (h *MembersHandler) membersRotateSecret(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "memberId")
+
+	data := struct {
+		Secret string `json:"secret"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		utils.HttpBadRequest(w, err)
+		return
+	}
+
+	if err := h.sessions.RotateMemberSecret(id, data.Secret); err != nil {
+		utils.HttpUnprocessableEntity(w, err)
+		return
+	}
+
+	utils.HttpSuccess(w)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"demodesk/neko/internal/types"
+	"demodesk/neko/internal/utils"
+)
+
+// SessionsHandler exposes admin endpoints for listing and revoking active
+// session tokens. It performs no authorization check of its own.
+type SessionsHandler struct {
+	sessions types.SessionManager
+}
+
+func NewSessionsHandler(sessions types.SessionManager) *SessionsHandler {
+	return &SessionsHandler{
+		sessions: sessions,
+	}
+}
+
+func (h *SessionsHandler) Route(r chi.Router) {
+	r.Get("/", h.sessionsList)
+	r.Delete("/{id}", h.sessionsRevoke)
+}
+
+func (h *SessionsHandler) sessionsList(w http.ResponseWriter, r *http.Request) {
+	utils.HttpSuccess(w, h.sessions.ActiveSessions())
+}
+
+func (h *SessionsHandler) sessionsRevoke(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.sessions.RevokeSession(id); err != nil {
+		utils.HttpUnprocessableEntity(w, err)
+		return
+	}
+
+	utils.HttpSuccess(w)
+}
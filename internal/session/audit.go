@@ -0,0 +1,173 @@
+package session
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"demodesk/neko/internal/config"
+)
+
+// AuditEvent is a single structured record describing a mutation on the
+// session manager: an authentication attempt, a session or member change,
+// a host grant/release, or an admin action.
+type AuditEvent struct {
+	Sequence  uint64    `json:"sequence"`
+	Time      time.Time `json:"time"`
+	Type      string    `json:"type"`
+	MemberID  string    `json:"member_id,omitempty"`
+	Provider  string    `json:"provider,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	Success   bool      `json:"success"`
+	Detail    string    `json:"detail,omitempty"`
+}
+
+// AuditSink receives every audit event recorded by the session manager.
+type AuditSink interface {
+	Write(event AuditEvent) error
+}
+
+func newAuditSink(config *config.Session) (AuditSink, error) {
+	switch config.AuditSinkBackend {
+	case "file":
+		return newFileAuditSink(config.AuditSinkFile)
+	case "syslog":
+		return newSyslogAuditSink(config.AuditSyslogNetwork, config.AuditSyslogAddr)
+	case "webhook":
+		return newWebhookAuditSink(config.AuditWebhookURL), nil
+	case "", "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink backend: %s", config.AuditSinkBackend)
+	}
+}
+
+// auditQueueSize bounds how many events may be waiting for the sink
+// goroutine before record() starts dropping them rather than blocking.
+const auditQueueSize = 256
+
+// auditLogCtx assigns monotonic sequence numbers to audit events, keeps a
+// bounded in-memory tail for the admin API, and forwards every event to the
+// configured AuditSink from a single background goroutine. record() never
+// performs sink I/O itself, so a slow or unreachable sink (e.g. the 5s
+// webhook timeout) cannot stall callers holding manager locks.
+type auditLogCtx struct {
+	logger zerolog.Logger
+	sink   AuditSink
+	seq    uint64
+
+	mu   sync.Mutex
+	tail []AuditEvent
+	max  int
+
+	queue chan AuditEvent
+}
+
+func newAuditLog(sink AuditSink, max int) *auditLogCtx {
+	if max <= 0 {
+		max = 200
+	}
+
+	a := &auditLogCtx{
+		logger: log.With().Str("module", "session").Str("submodule", "audit").Logger(),
+		sink:   sink,
+		max:    max,
+		queue:  make(chan AuditEvent, auditQueueSize),
+	}
+
+	if sink != nil {
+		go a.run()
+	}
+
+	return a
+}
+
+// run writes queued events to the sink one at a time, off the caller's path.
+func (a *auditLogCtx) run() {
+	for event := range a.queue {
+		if err := a.sink.Write(event); err != nil {
+			a.logger.Warn().Err(err).Str("type", event.Type).Msg("failed to write audit event")
+		}
+	}
+}
+
+func (a *auditLogCtx) record(event AuditEvent) {
+	event.Sequence = atomic.AddUint64(&a.seq, 1)
+	event.Time = time.Now()
+
+	a.mu.Lock()
+	a.tail = append(a.tail, event)
+	if len(a.tail) > a.max {
+		a.tail = a.tail[len(a.tail)-a.max:]
+	}
+	a.mu.Unlock()
+
+	if a.sink == nil {
+		return
+	}
+
+	select {
+	case a.queue <- event:
+	default:
+		a.logger.Warn().Str("type", event.Type).Msg("audit queue full, dropping event")
+	}
+}
+
+// Tail returns the most recent audit events, newest last, up to limit (or
+// every retained event if limit is <= 0).
+func (a *auditLogCtx) Tail(limit int) []AuditEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if limit <= 0 || limit > len(a.tail) {
+		limit = len(a.tail)
+	}
+
+	out := make([]AuditEvent, limit)
+	copy(out, a.tail[len(a.tail)-limit:])
+	return out
+}
+
+// AuditTail returns the most recent audit events recorded by the manager.
+func (manager *SessionManagerCtx) AuditTail(limit int) []AuditEvent {
+	return manager.audit.Tail(limit)
+}
+
+// requestMeta extracts the client IP and user agent used for audit records.
+// X-Forwarded-For is only honored when the immediate peer is one of the
+// configured trusted proxies; otherwise any client could spoof it to have
+// an arbitrary IP attributed to its requests in the audit trail.
+func (manager *SessionManagerCtx) requestMeta(r *http.Request) (ip string, userAgent string) {
+	ip = r.RemoteAddr
+
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" && manager.isTrustedProxy(ip) {
+		ip = strings.TrimSpace(strings.Split(fwd, ",")[0])
+	}
+
+	return ip, r.UserAgent()
+}
+
+// isTrustedProxy reports whether remoteAddr's host matches one of the
+// configured trusted proxies.
+func (manager *SessionManagerCtx) isTrustedProxy(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	for _, proxy := range manager.config.TrustedProxies {
+		if proxy == host {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,35 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// fileAuditSinkCtx appends one JSON object per line to a file.
+type fileAuditSinkCtx struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFileAuditSink(path string) (*fileAuditSinkCtx, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileAuditSinkCtx{file: file}, nil
+}
+
+func (s *fileAuditSinkCtx) Write(event AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.file.Write(append(data, '\n'))
+	return err
+}
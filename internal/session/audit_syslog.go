@@ -0,0 +1,34 @@
+package session
+
+import (
+	"encoding/json"
+	"log/syslog"
+)
+
+// syslogAuditSinkCtx forwards audit events to a syslog daemon, tagged as
+// "neko" and logged at info or warning level depending on outcome.
+type syslogAuditSinkCtx struct {
+	writer *syslog.Writer
+}
+
+func newSyslogAuditSink(network, addr string) (*syslogAuditSinkCtx, error) {
+	writer, err := syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_AUTH, "neko")
+	if err != nil {
+		return nil, err
+	}
+
+	return &syslogAuditSinkCtx{writer: writer}, nil
+}
+
+func (s *syslogAuditSinkCtx) Write(event AuditEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if !event.Success {
+		return s.writer.Warning(string(data))
+	}
+
+	return s.writer.Info(string(data))
+}
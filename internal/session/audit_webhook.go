@@ -0,0 +1,41 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// webhookAuditSinkCtx POSTs each audit event as JSON to a configured URL.
+type webhookAuditSinkCtx struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookAuditSink(url string) *webhookAuditSinkCtx {
+	return &webhookAuditSinkCtx{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (s *webhookAuditSinkCtx) Write(event AuditEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
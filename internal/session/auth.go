@@ -6,32 +6,80 @@ import (
 	"strings"
 
 	"demodesk/neko/internal/types"
-	"demodesk/neko/internal/utils"
 )
 
 const (
 	token_name = "password"
+	id_name    = "username"
 )
 
+// AuthProvider resolves an incoming HTTP request to a member id and profile.
+// SessionManagerCtx holds an ordered list of providers and tries each of
+// them in turn until one succeeds.
+type AuthProvider interface {
+	// Name returns the config-facing identifier of the provider, e.g. "password", "oidc" or "jwt".
+	Name() string
+	// Authenticate inspects the request and returns the id and profile of the member it belongs to.
+	Authenticate(r *http.Request) (id string, profile types.MemberProfile, err error)
+}
+
+// Authenticate resolves an incoming request to a session. It first tries to
+// reuse an already-issued session token; if none is present or it has
+// expired, it falls through to the configured auth providers and, on
+// success, issues a fresh token via the session's token manager.
 func (manager *SessionManagerCtx) Authenticate(r *http.Request) (types.Session, error) {
-	token := getToken(r)
-	if token == "" {
-		return nil, fmt.Errorf("no password provided")
+	if len(manager.authProviders) == 0 {
+		return nil, fmt.Errorf("no auth providers configured")
 	}
 
-	isAdmin := (token == manager.config.AdminPassword)
-	isUser := (token == manager.config.Password)
+	ip, userAgent := manager.requestMeta(r)
 
-	if !isAdmin && !isUser {
-		return nil, fmt.Errorf("invalid password")
+	if token := getToken(r); token != "" {
+		if _, ok := manager.tokens.Touch(token); ok {
+			if session, ok := manager.Get(token); ok {
+				return session, nil
+			}
+		} else {
+			// the token expired (or never existed); tear down any runtime
+			// session still hanging onto it instead of just blocking reuse
+			manager.revokeToken(token)
+		}
 	}
 
-	id, err := utils.NewUID(32)
-	if err != nil {
-		return nil, err
+	var lastErr error
+	for _, provider := range manager.authProviders {
+		memberId, profile, err := provider.Authenticate(r)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		token, evicted, err := manager.tokens.Issue(memberId)
+		if err != nil {
+			manager.audit.record(AuditEvent{
+				Type: "auth_attempt", MemberID: memberId, Provider: provider.Name(),
+				IP: ip, UserAgent: userAgent, Success: false, Detail: err.Error(),
+			})
+			return nil, err
+		}
+
+		if evicted != "" {
+			manager.revokeToken(evicted)
+		}
+
+		manager.audit.record(AuditEvent{
+			Type: "auth_attempt", MemberID: memberId, Provider: provider.Name(),
+			IP: ip, UserAgent: userAgent, Success: true,
+		})
+
+		return manager.Create(token, memberId, profile), nil
 	}
 
-	return manager.New(id, isAdmin), nil
+	manager.audit.record(AuditEvent{
+		Type: "auth_attempt", IP: ip, UserAgent: userAgent, Success: false, Detail: lastErr.Error(),
+	})
+
+	return nil, lastErr
 }
 
 func getToken(r *http.Request) string {
@@ -54,3 +102,18 @@ func getToken(r *http.Request) string {
 
 	return ""
 }
+
+func getID(r *http.Request) string {
+	// Get id from query
+	if id := r.URL.Query().Get(id_name); id != "" {
+		return id
+	}
+
+	// Get id from cookie
+	cookie, err := r.Cookie(id_name)
+	if err == nil {
+		return cookie.Value
+	}
+
+	return ""
+}
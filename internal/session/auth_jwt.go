@@ -0,0 +1,75 @@
+package session
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"demodesk/neko/internal/config"
+	"demodesk/neko/internal/types"
+)
+
+// jwtClaims are the claims neko expects on a signed bearer token, on top of
+// the standard registered claims.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Name    string `json:"name"`
+	IsAdmin bool   `json:"is_admin"`
+}
+
+// jwtProviderCtx authenticates requests bearing a signed JWT bearer token,
+// verified either with a shared HMAC secret (HS256) or a configured RSA
+// public key (RS256).
+type jwtProviderCtx struct {
+	config *config.Session
+}
+
+func newJWTProvider(config *config.Session) *jwtProviderCtx {
+	return &jwtProviderCtx{
+		config: config,
+	}
+}
+
+func (p *jwtProviderCtx) Name() string {
+	return "jwt"
+}
+
+func (p *jwtProviderCtx) Authenticate(r *http.Request) (string, types.MemberProfile, error) {
+	token := getToken(r)
+	if token == "" {
+		return "", types.MemberProfile{}, fmt.Errorf("no token provided")
+	}
+
+	claims := &jwtClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, p.keyFunc); err != nil {
+		return "", types.MemberProfile{}, fmt.Errorf("invalid jwt: %w", err)
+	}
+
+	if claims.Subject == "" {
+		return "", types.MemberProfile{}, fmt.Errorf("jwt is missing a subject claim")
+	}
+
+	roles := []string{string(RoleWatcher)}
+	if claims.IsAdmin {
+		roles = []string{string(RoleAdmin)}
+	}
+
+	return claims.Subject, types.MemberProfile{
+		Secret:  token,
+		Name:    claims.Name,
+		IsAdmin: claims.IsAdmin,
+		Roles:   roles,
+	}, nil
+}
+
+func (p *jwtProviderCtx) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return []byte(p.config.JWTSecret), nil
+	case *jwt.SigningMethodRSA:
+		return p.config.JWTPublicKey, nil
+	default:
+		return nil, fmt.Errorf("unexpected jwt signing method: %v", token.Header["alg"])
+	}
+}
@@ -0,0 +1,129 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/oauth2"
+
+	"demodesk/neko/internal/config"
+	"demodesk/neko/internal/types"
+)
+
+// oidcClaims are the claims neko reads out of the OIDC provider's id_token.
+// expectedAudience/expectedIssuer are populated before parsing and checked
+// by Valid, since jwt/v4's RegisteredClaims.Valid only checks exp/iat/nbf.
+type oidcClaims struct {
+	jwt.RegisteredClaims
+	Name   string   `json:"name"`
+	Groups []string `json:"groups"`
+
+	expectedAudience string `json:"-"`
+	expectedIssuer   string `json:"-"`
+}
+
+func (c *oidcClaims) Valid() error {
+	if err := c.RegisteredClaims.Valid(); err != nil {
+		return err
+	}
+
+	if !c.VerifyAudience(c.expectedAudience, true) {
+		return fmt.Errorf("id_token audience does not match configured client id")
+	}
+
+	if !c.VerifyIssuer(c.expectedIssuer, true) {
+		return fmt.Errorf("id_token issuer does not match configured issuer")
+	}
+
+	return nil
+}
+
+// oidcProviderCtx authenticates requests carrying an OIDC authorization
+// code, exchanges it for tokens with the configured issuer, verifies the
+// id_token's signature against the issuer's published JWKS, and maps its
+// groups onto the admin flag.
+type oidcProviderCtx struct {
+	config *config.Session
+	jwks   *jwksCtx
+}
+
+func newOIDCProvider(config *config.Session) *oidcProviderCtx {
+	return &oidcProviderCtx{
+		config: config,
+		jwks:   newJWKS(config.OIDCJWKSURL),
+	}
+}
+
+func (p *oidcProviderCtx) Name() string {
+	return "oidc"
+}
+
+func (p *oidcProviderCtx) oauth2Config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.OIDCClientID,
+		ClientSecret: p.config.OIDCClientSecret,
+		RedirectURL:  p.config.OIDCRedirectURL,
+		Scopes:       []string{"openid", "profile", "groups"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  p.config.OIDCIssuer + "/authorize",
+			TokenURL: p.config.OIDCIssuer + "/token",
+		},
+	}
+}
+
+// AuthCodeURL returns the URL the browser should be redirected to in order
+// to start the OIDC login flow.
+func (p *oidcProviderCtx) AuthCodeURL(state string) string {
+	return p.oauth2Config().AuthCodeURL(state)
+}
+
+func (p *oidcProviderCtx) Authenticate(r *http.Request) (string, types.MemberProfile, error) {
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		return "", types.MemberProfile{}, fmt.Errorf("no oidc authorization code provided")
+	}
+
+	token, err := p.oauth2Config().Exchange(context.Background(), code)
+	if err != nil {
+		return "", types.MemberProfile{}, fmt.Errorf("oidc code exchange failed: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return "", types.MemberProfile{}, fmt.Errorf("oidc response is missing an id_token")
+	}
+
+	claims := &oidcClaims{
+		expectedAudience: p.config.OIDCClientID,
+		expectedIssuer:   p.config.OIDCIssuer,
+	}
+	if _, err := jwt.ParseWithClaims(rawIDToken, claims, p.jwks.keyFunc); err != nil {
+		return "", types.MemberProfile{}, fmt.Errorf("invalid id_token: %w", err)
+	}
+
+	if claims.Subject == "" {
+		return "", types.MemberProfile{}, fmt.Errorf("id_token is missing a subject claim")
+	}
+
+	isAdmin := false
+	for _, group := range claims.Groups {
+		if group == p.config.OIDCAdminGroup {
+			isAdmin = true
+			break
+		}
+	}
+
+	roles := []string{string(RoleWatcher)}
+	if isAdmin {
+		roles = []string{string(RoleAdmin)}
+	}
+
+	return claims.Subject, types.MemberProfile{
+		Secret:  rawIDToken,
+		Name:    claims.Name,
+		IsAdmin: isAdmin,
+		Roles:   roles,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package session
+
+import (
+	"fmt"
+	"net/http"
+
+	"demodesk/neko/internal/types"
+	"demodesk/neko/internal/utils"
+)
+
+// passwordProviderCtx is the built-in auth provider that resolves a member
+// id and secret against the configured MemberStore.
+type passwordProviderCtx struct {
+	store MemberStore
+}
+
+func newPasswordProvider(store MemberStore) *passwordProviderCtx {
+	return &passwordProviderCtx{
+		store: store,
+	}
+}
+
+func (p *passwordProviderCtx) Name() string {
+	return "password"
+}
+
+func (p *passwordProviderCtx) Authenticate(r *http.Request) (string, types.MemberProfile, error) {
+	id := getID(r)
+	secret := getToken(r)
+	if id == "" || secret == "" {
+		return "", types.MemberProfile{}, fmt.Errorf("no credentials provided")
+	}
+
+	profile, err := p.store.Select(id)
+	if err != nil {
+		return "", types.MemberProfile{}, fmt.Errorf("invalid credentials")
+	}
+
+	if err := utils.CompareSecret(profile.Secret, secret); err != nil {
+		return "", types.MemberProfile{}, fmt.Errorf("invalid credentials")
+	}
+
+	return id, profile, nil
+}
@@ -0,0 +1,133 @@
+package session
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// jwksCacheTTL bounds how long a fetched key set is trusted before the next
+// lookup re-fetches it, so a provider's key rotation is picked up promptly.
+const jwksCacheTTL = 10 * time.Minute
+
+// jwk is a single entry of a JSON Web Key Set, as returned by an OIDC
+// provider's jwks_uri. Only RSA keys are supported, matching the RS256
+// id_tokens issued by every provider neko has been run against so far.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+// jwksCtx fetches and caches an OIDC provider's signing keys, so each
+// id_token verification doesn't need a round trip to the provider.
+type jwksCtx struct {
+	url string
+
+	mu      sync.Mutex
+	fetched time.Time
+	keys    map[string]*rsa.PublicKey
+}
+
+func newJWKS(url string) *jwksCtx {
+	return &jwksCtx{
+		url:  url,
+		keys: map[string]*rsa.PublicKey{},
+	}
+}
+
+// keyFunc is passed to jwt.ParseWithClaims to resolve the RSA public key
+// matching an id_token's "kid" header.
+func (j *jwksCtx) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("unexpected id_token signing method: %v", token.Header["alg"])
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("id_token is missing a kid header")
+	}
+
+	return j.key(kid)
+}
+
+func (j *jwksCtx) key(kid string) (*rsa.PublicKey, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if key, ok := j.keys[kid]; ok && time.Since(j.fetched) < jwksCacheTTL {
+		return key, nil
+	}
+
+	if err := j.refresh(); err != nil {
+		return nil, err
+	}
+
+	key, ok := j.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("no jwks key found for kid %q", kid)
+	}
+
+	return key, nil
+}
+
+// refresh re-fetches the key set. Callers must hold j.mu.
+func (j *jwksCtx) refresh() error {
+	resp, err := http.Get(j.url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var set jwkSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+
+		key, err := k.rsaPublicKey()
+		if err != nil {
+			continue
+		}
+
+		keys[k.Kid] = key
+	}
+
+	j.keys = keys
+	j.fetched = time.Now()
+	return nil
+}
+
+func (k jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwk modulus: %w", err)
+	}
+
+	eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwk exponent: %w", err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
@@ -3,6 +3,7 @@ package session
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/kataras/go-events"
 	"github.com/rs/zerolog"
@@ -13,58 +14,120 @@ import (
 	"demodesk/neko/internal/utils"
 )
 
+// errSessionNotFound is returned when an id has no matching runtime
+// session; it does not imply anything about the member store.
+var errSessionNotFound = fmt.Errorf("session not found")
+
 func New(capture types.CaptureManager, config *config.Session) *SessionManagerCtx {
+	store, err := newMemberStore(config)
+	if err != nil {
+		log.Panic().Err(err).Msg("unable to initialize member store")
+	}
+
+	auditSink, err := newAuditSink(config)
+	if err != nil {
+		log.Panic().Err(err).Msg("unable to initialize audit sink")
+	}
+
 	manager := &SessionManagerCtx{
 		logger:    log.With().Str("module", "session").Logger(),
 		host:      nil,
 		hostMu:    sync.Mutex{},
 		capture:   capture,
 		config:    config,
+		store:     store,
+		tokens:    newTokenManager(config.SessionTTL, config.MaxConcurrentSessions, config.EvictOldestSession),
+		audit:     newAuditLog(auditSink, config.AuditTailSize),
 		members:   make(map[string]*SessionCtx),
 		membersMu: sync.Mutex{},
 		emmiter:   events.New(),
 	}
 
-	// create default admin account at startup
-	_ = manager.Create("admin", types.MemberProfile{
-		Secret: config.AdminPassword,
-		Name: "Administrator",
-		IsAdmin: true,
-	})
+	// password auth is always available, and is resolved against the member store
+	manager.authProviders = append(manager.authProviders, newPasswordProvider(store))
 
-	// create default user account at startup
-	_ = manager.Create("user", types.MemberProfile{
-		Secret: config.Password,
-		Name: "User",
-		IsAdmin: false,
-	})
+	if config.OIDCEnabled {
+		manager.authProviders = append(manager.authProviders, newOIDCProvider(config))
+	}
+
+	if config.JWTEnabled {
+		manager.authProviders = append(manager.authProviders, newJWTProvider(config))
+	}
+
+	// preserve the previous, storeless behaviour by seeding the shared
+	// admin/user passwords into the store as regular members
+	manager.seedDefaultMembers()
 
 	return manager
 }
 
+// seedDefaultMembers ensures the admin/user accounts derived from the
+// shared passwords exist in the member store, for backwards compatibility
+// with the previous, storeless authentication.
+func (manager *SessionManagerCtx) seedDefaultMembers() {
+	seed := func(id, secret, name string, isAdmin bool, roles []string) {
+		if secret == "" {
+			return
+		}
+
+		hash, err := utils.HashSecret(secret)
+		if err != nil {
+			manager.logger.Error().Err(err).Str("id", id).Msg("unable to hash default member secret")
+			return
+		}
+
+		now := time.Now()
+		err = manager.store.Insert(id, types.MemberProfile{
+			Secret:    hash,
+			Name:      name,
+			IsAdmin:   isAdmin,
+			Roles:     roles,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil && err != ErrMemberAlreadyExists {
+			manager.logger.Error().Err(err).Str("id", id).Msg("unable to seed default member")
+		}
+	}
+
+	seed("admin", manager.config.AdminPassword, "Administrator", true, []string{string(RoleAdmin)})
+	seed("user", manager.config.Password, "User", false, []string{string(RoleController)})
+}
+
 type SessionManagerCtx struct {
 	logger    zerolog.Logger
 	host      types.Session
 	hostMu    sync.Mutex
 	capture   types.CaptureManager
 	config    *config.Session
+	store     MemberStore
+	tokens    *tokenManagerCtx
+	audit     *auditLogCtx
 	members   map[string]*SessionCtx
 	membersMu sync.Mutex
 	emmiter   events.EventEmmiter
+
+	authProviders []AuthProvider
 }
 
-func (manager *SessionManagerCtx) Create(id string, profile types.MemberProfile) types.Session {
+// Create registers a new runtime session under the given token, tied to
+// memberId. The token, not the member id, is used as the map key so that a
+// single member may hold several concurrent sessions.
+func (manager *SessionManagerCtx) Create(id string, memberId string, profile types.MemberProfile) types.Session {
 	manager.membersMu.Lock()
 	defer manager.membersMu.Unlock()
 
 	session := &SessionCtx{
 		id:        id,
+		memberId:  memberId,
 		manager:   manager,
-		logger:    manager.logger.With().Str("id", id).Logger(),
+		logger:    manager.logger.With().Str("id", id).Str("member_id", memberId).Logger(),
 		profile:   profile,
 	}
 
 	manager.members[id] = session
+	manager.audit.record(AuditEvent{Type: "session_create", MemberID: memberId, Success: true})
+
 	return session
 }
 
@@ -82,10 +145,11 @@ func (manager *SessionManagerCtx) Delete(id string) error {
 
 	session, ok := manager.members[id]
 	if !ok {
-		return fmt.Errorf("Member not found.")
+		return errSessionNotFound
 	}
 
 	delete(manager.members, id)
+	manager.audit.record(AuditEvent{Type: "session_delete", MemberID: session.memberId, Success: true})
 
 	if session.IsConnected() {
 		return session.Disconnect("member deleted")
@@ -111,6 +175,7 @@ func (manager *SessionManagerCtx) SetHost(host types.Session) {
 
 	manager.host = host
 	manager.emmiter.Emit("host", host)
+	manager.audit.record(AuditEvent{Type: "host_grant", MemberID: sessionMemberID(host), Success: true})
 }
 
 func (manager *SessionManagerCtx) GetHost() types.Session {
@@ -127,6 +192,20 @@ func (manager *SessionManagerCtx) ClearHost() {
 	host := manager.host
 	manager.host = nil
 	manager.emmiter.Emit("host_cleared", host)
+
+	if host != nil {
+		manager.audit.record(AuditEvent{Type: "host_release", MemberID: sessionMemberID(host), Success: true})
+	}
+}
+
+// sessionMemberID returns the member id backing a types.Session, or an
+// empty string if it is nil or of an unexpected concrete type.
+func sessionMemberID(s types.Session) string {
+	if session, ok := s.(*SessionCtx); ok {
+		return session.memberId
+	}
+
+	return ""
 }
 
 // ---
@@ -179,12 +258,23 @@ func (manager *SessionManagerCtx) Broadcast(v interface{}, exclude interface{})
 	}
 }
 
+// AdminBroadcast sends v to every connected member capable of managing
+// other members. It is kept as a named alias of BroadcastByCap(CapManageMembers, ...)
+// since call sites throughout the codebase already refer to "admin" broadcasts.
 func (manager *SessionManagerCtx) AdminBroadcast(v interface{}, exclude interface{}) {
+	manager.audit.record(AuditEvent{Type: "admin_broadcast", Success: true})
+
+	manager.BroadcastByCap(CapManageMembers, v, exclude)
+}
+
+// BroadcastByCap sends v to every connected member holding the given
+// capability, mirroring AdminBroadcast but for arbitrary capabilities.
+func (manager *SessionManagerCtx) BroadcastByCap(cap Capability, v interface{}, exclude interface{}) {
 	manager.membersMu.Lock()
 	defer manager.membersMu.Unlock()
 
 	for id, session := range manager.members {
-		if !session.IsConnected() || !session.IsAdmin() {
+		if !session.IsConnected() || !session.HasCapability(cap) {
 			continue
 		}
 
@@ -195,11 +285,57 @@ func (manager *SessionManagerCtx) AdminBroadcast(v interface{}, exclude interfac
 		}
 
 		if err := session.Send(v); err != nil {
-			manager.logger.Warn().Err(err).Msgf("broadcasting admin event has failed")
+			manager.logger.Warn().Err(err).Msgf("broadcasting capability event has failed")
 		}
 	}
 }
 
+// ---
+// revocation
+// ---
+
+// RevokeSession invalidates a single active session, identified by the
+// opaque id returned from ActiveSessions (never the bearer token itself),
+// disconnecting it if it is currently connected.
+func (manager *SessionManagerCtx) RevokeSession(id string) error {
+	token, ok := manager.tokens.RevokeByID(id)
+	if !ok {
+		return fmt.Errorf("session id not found")
+	}
+
+	manager.revokeToken(token)
+	return nil
+}
+
+// RevokeAllSessionsFor invalidates every active session token belonging to
+// memberId, disconnecting each one that is currently connected.
+func (manager *SessionManagerCtx) RevokeAllSessionsFor(memberId string) {
+	for _, token := range manager.tokens.RevokeAllFor(memberId) {
+		manager.revokeToken(token)
+	}
+}
+
+// ActiveSessions lists every currently issued session token, for the admin
+// sessions endpoint.
+func (manager *SessionManagerCtx) ActiveSessions() []ActiveToken {
+	return manager.tokens.List()
+}
+
+// revokeToken tears down the runtime session behind an already-revoked
+// token and emits a session_revoked event.
+func (manager *SessionManagerCtx) revokeToken(token string) {
+	session, ok := manager.Get(token)
+	if !ok {
+		return
+	}
+
+	manager.emmiter.Emit("session_revoked", session)
+
+	if err := manager.Delete(token); err != nil {
+		manager.logger.Warn().Err(err).Str("id", token).Msg("failed to disconnect revoked session")
+	}
+}
+
 // ---
 // events
 // ---
@@ -210,6 +346,12 @@ func (manager *SessionManagerCtx) OnHost(listener func(session types.Session)) {
 	})
 }
 
+func (manager *SessionManagerCtx) OnSessionRevoked(listener func(session types.Session)) {
+	manager.emmiter.On("session_revoked", func(payload ...interface{}) {
+		listener(payload[0].(*SessionCtx))
+	})
+}
+
 func (manager *SessionManagerCtx) OnHostCleared(listener func(session types.Session)) {
 	manager.emmiter.On("host_cleared", func(payload ...interface{}) {
 		listener(payload[0].(*SessionCtx))
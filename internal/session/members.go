@@ -0,0 +1,132 @@
+package session
+
+import (
+	"time"
+
+	"demodesk/neko/internal/types"
+	"demodesk/neko/internal/utils"
+)
+
+// ListMembers returns every member profile known to the member store.
+func (manager *SessionManagerCtx) ListMembers() (map[string]types.MemberProfile, error) {
+	return manager.store.SelectAll()
+}
+
+// CreateMember hashes the given secret and inserts a new member into the
+// store. It does not affect any currently connected session.
+func (manager *SessionManagerCtx) CreateMember(id string, profile types.MemberProfile) error {
+	hash, err := utils.HashSecret(profile.Secret)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	profile.Secret = hash
+	profile.CreatedAt = now
+	profile.UpdatedAt = now
+
+	if err := manager.store.Insert(id, profile); err != nil {
+		return err
+	}
+
+	manager.audit.record(AuditEvent{Type: "member_create", MemberID: id, Success: true})
+	return nil
+}
+
+// UpdateMember overwrites a member's profile in the store, hashing the
+// secret if one was provided and preserving the original CreatedAt. The
+// audit event is "capability_change" only when roles or permissions
+// actually changed, so a plain rename isn't indistinguishable from a
+// privilege escalation in the audit trail.
+func (manager *SessionManagerCtx) UpdateMember(id string, profile types.MemberProfile) error {
+	existing, err := manager.store.Select(id)
+	if err != nil {
+		return err
+	}
+
+	if profile.Secret != "" {
+		hash, err := utils.HashSecret(profile.Secret)
+		if err != nil {
+			return err
+		}
+
+		profile.Secret = hash
+	} else {
+		profile.Secret = existing.Secret
+	}
+
+	profile.CreatedAt = existing.CreatedAt
+	profile.UpdatedAt = time.Now()
+
+	if err := manager.store.Update(id, profile); err != nil {
+		return err
+	}
+
+	eventType := "member_update"
+	if capabilitiesChanged(existing, profile) {
+		eventType = "capability_change"
+	}
+	manager.audit.record(AuditEvent{Type: eventType, MemberID: id, Success: true})
+
+	return nil
+}
+
+// capabilitiesChanged reports whether an update changes anything that
+// affects what a member is allowed to do.
+func capabilitiesChanged(existing, updated types.MemberProfile) bool {
+	return existing.IsAdmin != updated.IsAdmin ||
+		existing.CanHost != updated.CanHost ||
+		existing.CanControl != updated.CanControl ||
+		existing.CanWatch != updated.CanWatch ||
+		!rolesEqual(existing.Roles, updated.Roles)
+}
+
+func rolesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeleteMember removes a member from the store and disconnects every
+// active session it holds.
+func (manager *SessionManagerCtx) DeleteMember(id string) error {
+	if err := manager.store.Delete(id); err != nil {
+		return err
+	}
+
+	manager.audit.record(AuditEvent{Type: "member_delete", MemberID: id, Success: true})
+	manager.RevokeAllSessionsFor(id)
+
+	return nil
+}
+
+// RotateMemberSecret assigns a new, freshly hashed secret to a member.
+func (manager *SessionManagerCtx) RotateMemberSecret(id string, secret string) error {
+	profile, err := manager.store.Select(id)
+	if err != nil {
+		return err
+	}
+
+	hash, err := utils.HashSecret(secret)
+	if err != nil {
+		return err
+	}
+
+	profile.Secret = hash
+	profile.UpdatedAt = time.Now()
+
+	if err := manager.store.Update(id, profile); err != nil {
+		return err
+	}
+
+	manager.audit.record(AuditEvent{Type: "secret_rotate", MemberID: id, Success: true})
+	return nil
+}
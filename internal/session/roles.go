@@ -0,0 +1,79 @@
+package session
+
+import (
+	"demodesk/neko/internal/types"
+)
+
+// Capability is a single bit describing an action a session is allowed to
+// perform. Capabilities are additive: a session's effective set is the
+// union of the capabilities granted by its roles and permission flags.
+type Capability uint32
+
+const (
+	CapControlInput Capability = 1 << iota
+	CapViewScreen
+	CapClipboardRead
+	CapClipboardWrite
+	CapFileUpload
+	CapInviteOthers
+	CapManageMembers
+	CapManageHost
+)
+
+// Role is a named, config-facing bundle of capabilities that can be
+// assigned to a member.
+type Role string
+
+const (
+	RoleWatcher    Role = "watcher"
+	RoleController Role = "controller"
+	RoleHost       Role = "host"
+	RoleAdmin      Role = "admin"
+)
+
+// roleCapabilities maps each built-in role onto the capabilities it grants.
+var roleCapabilities = map[Role]Capability{
+	RoleWatcher: CapViewScreen | CapClipboardRead,
+	RoleController: CapViewScreen | CapClipboardRead | CapClipboardWrite |
+		CapControlInput | CapFileUpload,
+	RoleHost: CapViewScreen | CapClipboardRead | CapClipboardWrite |
+		CapControlInput | CapFileUpload | CapInviteOthers | CapManageHost,
+	RoleAdmin: CapViewScreen | CapClipboardRead | CapClipboardWrite |
+		CapControlInput | CapFileUpload | CapInviteOthers | CapManageHost |
+		CapManageMembers,
+}
+
+// capabilitiesFromProfile computes a member's effective capability set from
+// its assigned roles, its IsAdmin flag, and the legacy can-host/can-control/
+// can-watch permission flags.
+func capabilitiesFromProfile(profile types.MemberProfile) Capability {
+	var caps Capability
+
+	for _, name := range profile.Roles {
+		caps |= roleCapabilities[Role(name)]
+	}
+
+	if profile.CanWatch {
+		caps |= roleCapabilities[RoleWatcher]
+	}
+
+	if profile.CanControl {
+		caps |= roleCapabilities[RoleController]
+	}
+
+	if profile.CanHost {
+		caps |= roleCapabilities[RoleHost]
+	}
+
+	if profile.IsAdmin {
+		caps |= roleCapabilities[RoleAdmin]
+	}
+
+	return caps
+}
+
+// HasCapability reports whether the session's member profile grants it the
+// given capability.
+func (session *SessionCtx) HasCapability(cap Capability) bool {
+	return capabilitiesFromProfile(session.profile)&cap != 0
+}
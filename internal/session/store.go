@@ -0,0 +1,46 @@
+package session
+
+import (
+	"fmt"
+
+	"demodesk/neko/internal/config"
+	"demodesk/neko/internal/types"
+)
+
+var (
+	// ErrMemberNotFound is returned by a MemberStore when no member exists for a given id.
+	ErrMemberNotFound = fmt.Errorf("member not found")
+	// ErrMemberAlreadyExists is returned by a MemberStore when inserting an id that is already taken.
+	ErrMemberAlreadyExists = fmt.Errorf("member already exists")
+)
+
+// MemberStore holds member profiles. The file and sql backends keep them
+// across restarts; the memory backend does not. Secrets handed to
+// Insert/Update are expected to already be hashed by the caller; the store
+// itself never hashes or compares secrets.
+type MemberStore interface {
+	// Insert adds a new member profile under the given id.
+	Insert(id string, profile types.MemberProfile) error
+	// Select returns the profile stored for the given id.
+	Select(id string) (types.MemberProfile, error)
+	// SelectAll returns every stored member profile, keyed by id.
+	SelectAll() (map[string]types.MemberProfile, error)
+	// Update overwrites the profile stored for the given id.
+	Update(id string, profile types.MemberProfile) error
+	// Delete removes the profile stored for the given id.
+	Delete(id string) error
+}
+
+// newMemberStore builds the MemberStore backend selected by config.
+func newMemberStore(config *config.Session) (MemberStore, error) {
+	switch config.MemberStoreBackend {
+	case "file":
+		return newFileMemberStore(config.MemberStoreFile)
+	case "sql":
+		return newSqlMemberStore(config.MemberStoreDSN)
+	case "memory", "":
+		return newMemoryMemberStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown member store backend: %s", config.MemberStoreBackend)
+	}
+}
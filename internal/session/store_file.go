@@ -0,0 +1,134 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"demodesk/neko/internal/types"
+)
+
+// fileMemberStoreCtx persists member profiles as a single JSON document on
+// disk. It re-reads the file on every call so that it stays correct even
+// if edited by hand, and rewrites it atomically after each mutation.
+type fileMemberStoreCtx struct {
+	mu   sync.Mutex
+	path string
+}
+
+func newFileMemberStore(path string) (*fileMemberStoreCtx, error) {
+	store := &fileMemberStoreCtx{
+		path: path,
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := store.save(map[string]types.MemberProfile{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+func (s *fileMemberStoreCtx) load() (map[string]types.MemberProfile, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	members := map[string]types.MemberProfile{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (s *fileMemberStoreCtx) save(members map[string]types.MemberProfile) error {
+	data, err := json.MarshalIndent(members, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path)
+}
+
+func (s *fileMemberStoreCtx) Insert(id string, profile types.MemberProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := members[id]; ok {
+		return ErrMemberAlreadyExists
+	}
+
+	members[id] = profile
+	return s.save(members)
+}
+
+func (s *fileMemberStoreCtx) Select(id string) (types.MemberProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members, err := s.load()
+	if err != nil {
+		return types.MemberProfile{}, err
+	}
+
+	profile, ok := members[id]
+	if !ok {
+		return types.MemberProfile{}, ErrMemberNotFound
+	}
+
+	return profile, nil
+}
+
+func (s *fileMemberStoreCtx) SelectAll() (map[string]types.MemberProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+func (s *fileMemberStoreCtx) Update(id string, profile types.MemberProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := members[id]; !ok {
+		return ErrMemberNotFound
+	}
+
+	members[id] = profile
+	return s.save(members)
+}
+
+func (s *fileMemberStoreCtx) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := members[id]; !ok {
+		return ErrMemberNotFound
+	}
+
+	delete(members, id)
+	return s.save(members)
+}
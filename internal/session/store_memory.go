@@ -0,0 +1,80 @@
+package session
+
+import (
+	"sync"
+
+	"demodesk/neko/internal/types"
+)
+
+// memoryMemberStoreCtx keeps member profiles in a plain map. It is the
+// default backend and matches the previous, non-persistent behaviour.
+type memoryMemberStoreCtx struct {
+	mu      sync.Mutex
+	members map[string]types.MemberProfile
+}
+
+func newMemoryMemberStore() *memoryMemberStoreCtx {
+	return &memoryMemberStoreCtx{
+		members: make(map[string]types.MemberProfile),
+	}
+}
+
+func (s *memoryMemberStoreCtx) Insert(id string, profile types.MemberProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.members[id]; ok {
+		return ErrMemberAlreadyExists
+	}
+
+	s.members[id] = profile
+	return nil
+}
+
+func (s *memoryMemberStoreCtx) Select(id string) (types.MemberProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.members[id]
+	if !ok {
+		return types.MemberProfile{}, ErrMemberNotFound
+	}
+
+	return profile, nil
+}
+
+func (s *memoryMemberStoreCtx) SelectAll() (map[string]types.MemberProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members := make(map[string]types.MemberProfile, len(s.members))
+	for id, profile := range s.members {
+		members[id] = profile
+	}
+
+	return members, nil
+}
+
+func (s *memoryMemberStoreCtx) Update(id string, profile types.MemberProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.members[id]; !ok {
+		return ErrMemberNotFound
+	}
+
+	s.members[id] = profile
+	return nil
+}
+
+func (s *memoryMemberStoreCtx) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.members[id]; !ok {
+		return ErrMemberNotFound
+	}
+
+	delete(s.members, id)
+	return nil
+}
@@ -0,0 +1,153 @@
+package session
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+
+	"demodesk/neko/internal/types"
+)
+
+const sqlMemberStoreSchema = `
+CREATE TABLE IF NOT EXISTS members (
+	id           TEXT PRIMARY KEY,
+	secret       TEXT NOT NULL,
+	name         TEXT NOT NULL,
+	is_admin     BOOLEAN NOT NULL DEFAULT 0,
+	roles        TEXT NOT NULL DEFAULT '',
+	can_host     BOOLEAN NOT NULL DEFAULT 1,
+	can_control  BOOLEAN NOT NULL DEFAULT 1,
+	can_watch    BOOLEAN NOT NULL DEFAULT 1,
+	created_at   DATETIME NOT NULL,
+	updated_at   DATETIME NOT NULL
+);
+`
+
+// sqlMemberStoreCtx persists member profiles in a SQL database. The DSN is
+// always opened with the sqlite3 driver; no other driver can be selected.
+type sqlMemberStoreCtx struct {
+	db *sql.DB
+}
+
+func newSqlMemberStore(dsn string) (*sqlMemberStoreCtx, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(sqlMemberStoreSchema); err != nil {
+		return nil, err
+	}
+
+	return &sqlMemberStoreCtx{db: db}, nil
+}
+
+// encodeRoles/decodeRoles store a member's role list as a comma-separated
+// string, since role names never contain commas.
+func encodeRoles(roles []string) string {
+	return strings.Join(roles, ",")
+}
+
+func decodeRoles(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+
+	return strings.Split(raw, ",")
+}
+
+func (s *sqlMemberStoreCtx) Insert(id string, profile types.MemberProfile) error {
+	_, err := s.db.Exec(`
+		INSERT INTO members (id, secret, name, is_admin, roles, can_host, can_control, can_watch, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, profile.Secret, profile.Name, profile.IsAdmin, encodeRoles(profile.Roles),
+		profile.CanHost, profile.CanControl, profile.CanWatch, profile.CreatedAt, profile.UpdatedAt)
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrConstraint {
+		return ErrMemberAlreadyExists
+	}
+
+	return err
+}
+
+func (s *sqlMemberStoreCtx) Select(id string) (types.MemberProfile, error) {
+	row := s.db.QueryRow(`
+		SELECT secret, name, is_admin, roles, can_host, can_control, can_watch, created_at, updated_at
+		FROM members WHERE id = ?`, id)
+
+	var profile types.MemberProfile
+	var roles string
+	err := row.Scan(&profile.Secret, &profile.Name, &profile.IsAdmin, &roles,
+		&profile.CanHost, &profile.CanControl, &profile.CanWatch, &profile.CreatedAt, &profile.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return types.MemberProfile{}, ErrMemberNotFound
+	}
+
+	profile.Roles = decodeRoles(roles)
+	return profile, err
+}
+
+func (s *sqlMemberStoreCtx) SelectAll() (map[string]types.MemberProfile, error) {
+	rows, err := s.db.Query(`
+		SELECT id, secret, name, is_admin, roles, can_host, can_control, can_watch, created_at, updated_at
+		FROM members`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := map[string]types.MemberProfile{}
+	for rows.Next() {
+		var id, roles string
+		var profile types.MemberProfile
+
+		if err := rows.Scan(&id, &profile.Secret, &profile.Name, &profile.IsAdmin, &roles,
+			&profile.CanHost, &profile.CanControl, &profile.CanWatch, &profile.CreatedAt, &profile.UpdatedAt); err != nil {
+			return nil, err
+		}
+
+		profile.Roles = decodeRoles(roles)
+		members[id] = profile
+	}
+
+	return members, rows.Err()
+}
+
+func (s *sqlMemberStoreCtx) Update(id string, profile types.MemberProfile) error {
+	res, err := s.db.Exec(`
+		UPDATE members
+		SET secret = ?, name = ?, is_admin = ?, roles = ?, can_host = ?, can_control = ?, can_watch = ?, updated_at = ?
+		WHERE id = ?`,
+		profile.Secret, profile.Name, profile.IsAdmin, encodeRoles(profile.Roles),
+		profile.CanHost, profile.CanControl, profile.CanWatch, profile.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func (s *sqlMemberStoreCtx) Delete(id string) error {
+	res, err := s.db.Exec(`DELETE FROM members WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(res)
+}
+
+func checkRowsAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrMemberNotFound
+	}
+
+	return nil
+}
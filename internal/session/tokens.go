@@ -0,0 +1,204 @@
+package session
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"demodesk/neko/internal/utils"
+)
+
+// ActiveToken is a snapshot of one issued session, as returned by the admin
+// sessions listing endpoint. ID is an opaque handle used to revoke the
+// session; the bearer token itself is never exposed here.
+type ActiveToken struct {
+	ID        string    `json:"id"`
+	MemberID  string    `json:"member_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type tokenEntry struct {
+	id        string
+	memberID  string
+	expiresAt time.Time
+}
+
+// tokenManagerCtx issues and tracks the bearer tokens handed out after a
+// successful login. It enforces a TTL with sliding refresh and an optional
+// cap on how many tokens a single member may hold concurrently. Each token
+// is also assigned an opaque id, so admin-facing APIs never need to round
+// trip the bearer token itself.
+type tokenManagerCtx struct {
+	mu       sync.Mutex
+	tokens   map[string]*tokenEntry
+	byID     map[string]string
+	byMember map[string][]string
+
+	ttl           time.Duration
+	maxConcurrent int
+	evictOldest   bool
+}
+
+func newTokenManager(ttl time.Duration, maxConcurrent int, evictOldest bool) *tokenManagerCtx {
+	return &tokenManagerCtx{
+		tokens:        make(map[string]*tokenEntry),
+		byID:          make(map[string]string),
+		byMember:      make(map[string][]string),
+		ttl:           ttl,
+		maxConcurrent: maxConcurrent,
+		evictOldest:   evictOldest,
+	}
+}
+
+// Issue mints a new token for memberID. If the member is already at its
+// concurrent session limit, it either evicts the member's oldest token or
+// rejects the new one, depending on evictOldest.
+func (tm *tokenManagerCtx) Issue(memberID string) (string, string, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	var evicted string
+	if tm.maxConcurrent > 0 && len(tm.byMember[memberID]) >= tm.maxConcurrent {
+		if !tm.evictOldest {
+			return "", "", fmt.Errorf("member has reached the maximum number of concurrent sessions")
+		}
+
+		evicted = tm.byMember[memberID][0]
+		tm.revokeLocked(evicted)
+	}
+
+	token, err := utils.NewUID(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	id, err := utils.NewUID(16)
+	if err != nil {
+		return "", "", err
+	}
+
+	tm.tokens[token] = &tokenEntry{
+		id:        id,
+		memberID:  memberID,
+		expiresAt: tm.expiryFrom(time.Now()),
+	}
+	tm.byID[id] = token
+	tm.byMember[memberID] = append(tm.byMember[memberID], token)
+
+	return token, evicted, nil
+}
+
+func (tm *tokenManagerCtx) expiryFrom(now time.Time) time.Time {
+	if tm.ttl <= 0 {
+		return time.Time{}
+	}
+
+	return now.Add(tm.ttl)
+}
+
+// Touch validates a token and, if TTLs are enabled, slides its expiry
+// forward. It returns the owning member id.
+func (tm *tokenManagerCtx) Touch(token string) (string, bool) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	entry, ok := tm.tokens[token]
+	if !ok {
+		return "", false
+	}
+
+	if tm.ttl > 0 && time.Now().After(entry.expiresAt) {
+		tm.revokeLocked(token)
+		return "", false
+	}
+
+	entry.expiresAt = tm.expiryFrom(time.Now())
+	return entry.memberID, true
+}
+
+// Revoke invalidates a single token.
+func (tm *tokenManagerCtx) Revoke(token string) bool {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	return tm.revokeLocked(token)
+}
+
+// RevokeByID invalidates the token behind an opaque session id, as used by
+// the admin sessions API, and returns the token so the caller can tear down
+// its runtime session.
+func (tm *tokenManagerCtx) RevokeByID(id string) (string, bool) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	token, ok := tm.byID[id]
+	if !ok {
+		return "", false
+	}
+
+	tm.revokeLocked(token)
+	return token, true
+}
+
+// RevokeAllFor invalidates every token belonging to memberID, returning the
+// tokens that were revoked.
+func (tm *tokenManagerCtx) RevokeAllFor(memberID string) []string {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tokens := tm.byMember[memberID]
+	revoked := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if entry, ok := tm.tokens[token]; ok {
+			delete(tm.byID, entry.id)
+		}
+		delete(tm.tokens, token)
+		revoked = append(revoked, token)
+	}
+	delete(tm.byMember, memberID)
+
+	return revoked
+}
+
+// List returns a snapshot of every currently active session.
+func (tm *tokenManagerCtx) List() []ActiveToken {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	list := make([]ActiveToken, 0, len(tm.tokens))
+	for _, entry := range tm.tokens {
+		list = append(list, ActiveToken{
+			ID:        entry.id,
+			MemberID:  entry.memberID,
+			ExpiresAt: entry.expiresAt,
+		})
+	}
+
+	return list
+}
+
+func (tm *tokenManagerCtx) revokeLocked(token string) bool {
+	entry, ok := tm.tokens[token]
+	if !ok {
+		return false
+	}
+
+	delete(tm.tokens, token)
+	delete(tm.byID, entry.id)
+
+	tokens := tm.byMember[entry.memberID]
+	for i, t := range tokens {
+		if t == token {
+			tokens = append(tokens[:i], tokens[i+1:]...)
+			break
+		}
+	}
+
+	if len(tokens) == 0 {
+		delete(tm.byMember, entry.memberID)
+	} else {
+		tm.byMember[entry.memberID] = tokens
+	}
+
+	return true
+}